@@ -1,6 +1,10 @@
 package inkscape
 
-import "fmt"
+import (
+	"fmt"
+	"image/color"
+	"strings"
+)
 
 /*
 action-list         :  Print a list of actions and exit.
@@ -169,3 +173,198 @@ func SelectList() string {
 func Version() string {
 	return "inkscape-version"
 }
+
+// Action represents a single inkscape shell action together with its
+// arguments, using the Inkscape 1.2+ "action-name:arg1,arg2" grammar.
+type Action struct {
+	Name string
+	Args []string
+}
+
+// NewAction creates an Action with the given name and arguments.
+func NewAction(name string, args ...string) Action {
+	return Action{Name: name, Args: args}
+}
+
+// String serializes the action the way inkscape's shell expects it,
+// e.g. "export-id:id1,id2" or "file-close" when there are no arguments.
+// The same serialization is understood by the legacy 1.0/1.1 shell for
+// the single-argument actions this module builds, so one code path
+// covers Inkscape 1.0 through 1.3.
+func (a Action) String() string {
+	if len(a.Args) == 0 {
+		return a.Name
+	}
+
+	return a.Name + ":" + strings.Join(a.Args, ",")
+}
+
+// ActionSpec describes a single action as reported by inkscape's
+// `action-list` command.
+type ActionSpec struct {
+	Name        string
+	ArgType     string
+	Description string
+}
+
+// ActionPipeline builds a sequence of actions that are sent together
+// as a single ";"-separated command to the inkscape shell. When built
+// with known action specs (see Proxy.DiscoverActions), Add and AddRaw
+// validate each action name and record the first unknown action
+// encountered.
+type ActionPipeline struct {
+	parts []string
+	specs map[string]ActionSpec
+	err   error
+}
+
+// NewActionPipeline creates an empty pipeline. specs may be nil, in
+// which case actions are added without validation.
+func NewActionPipeline(specs map[string]ActionSpec) *ActionPipeline {
+	return &ActionPipeline{specs: specs}
+}
+
+// Add appends an action to the pipeline, validating its name against
+// the pipeline's known specs, if any.
+func (p *ActionPipeline) Add(name string, args ...string) *ActionPipeline {
+	return p.addSerialized(name, NewAction(name, args...).String())
+}
+
+// AddRaw appends a pre-serialized "name:args" action, such as one
+// returned by the builder functions in action.go (e.g. FileOpen,
+// ExportFileName), validating its name the same way Add does.
+func (p *ActionPipeline) AddRaw(action string) *ActionPipeline {
+	name, _, _ := strings.Cut(action, ":")
+
+	return p.addSerialized(name, action)
+}
+
+func (p *ActionPipeline) addSerialized(name, serialized string) *ActionPipeline {
+	if p.specs != nil {
+		if _, ok := p.specs[name]; !ok {
+			if p.err == nil {
+				p.err = fmt.Errorf("unknown action `%s`", name)
+			}
+
+			return p
+		}
+	}
+
+	p.parts = append(p.parts, serialized)
+
+	return p
+}
+
+// Err returns the first validation error encountered while building
+// the pipeline, if any.
+func (p *ActionPipeline) Err() error {
+	return p.err
+}
+
+// String serializes the pipeline into a single ";"-separated command.
+func (p *ActionPipeline) String() string {
+	return strings.Join(p.parts, ";")
+}
+
+// parseActionList parses the two-column output of `action-list` into
+// a map keyed by action name, as printed in the comment block above.
+func parseActionList(raw []byte) map[string]ActionSpec {
+	specs := make(map[string]ActionSpec)
+
+	for _, line := range strings.Split(string(raw), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, description, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		name = strings.TrimSpace(name)
+		description = strings.TrimSpace(description)
+
+		argType := ""
+		if _, usage, ok := strings.Cut(description, "Usage:"); ok {
+			argType = strings.TrimSpace(usage)
+		}
+
+		specs[name] = ActionSpec{
+			Name:        name,
+			ArgType:     argType,
+			Description: description,
+		}
+	}
+
+	return specs
+}
+
+// ExportType .
+func ExportType(format ExportFormat) string {
+	return "export-type:" + string(format)
+}
+
+// ExportDPI .
+func ExportDPI(dpi float64) string {
+	return fmt.Sprintf("export-dpi:%g", dpi)
+}
+
+// ExportAreaPage .
+func ExportAreaPage() string {
+	return "export-area-page"
+}
+
+// ExportAreaDrawing .
+func ExportAreaDrawing() string {
+	return "export-area-drawing"
+}
+
+// ExportID .
+func ExportID(ids ...string) string {
+	return "export-id:" + strings.Join(ids, ",")
+}
+
+// ExportIDOnly .
+func ExportIDOnly() string {
+	return "export-id-only"
+}
+
+// ExportLatex .
+func ExportLatex() string {
+	return "export-latex"
+}
+
+// ExportPlainSVG .
+func ExportPlainSVG() string {
+	return "export-plain-svg"
+}
+
+// ExportPsLevel .
+func ExportPsLevel(level int) string {
+	return fmt.Sprintf("export-ps-level:%d", level)
+}
+
+// ExportTextToPath .
+func ExportTextToPath() string {
+	return "export-text-to-path"
+}
+
+// ExportBackground .
+func ExportBackground(c color.Color) string {
+	// color.Color.RGBA() returns alpha-premultiplied values; convert
+	// through color.NRGBAModel to recover the straight color inkscape
+	// expects, independent of the color's alpha channel.
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("export-background:#%02x%02x%02x", nrgba.R, nrgba.G, nrgba.B)
+}
+
+// ExportBackgroundOpacity .
+func ExportBackgroundOpacity(opacity float64) string {
+	return fmt.Sprintf("export-background-opacity:%g", opacity)
+}
+
+// QueryAll .
+func QueryAll() string {
+	return "query-all"
+}
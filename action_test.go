@@ -0,0 +1,90 @@
+package inkscape
+
+import "testing"
+
+func TestParseActionList(t *testing.T) {
+	raw := []byte(`
+file-open           :  Open file.
+query-all           :  Query 'x', 'y', 'width', and 'height'.
+object-set-attribute:  Set or update an attribute on selected objects. Usage: object-set-attribute:attribute name, attribute value;
+`)
+
+	specs := parseActionList(raw)
+
+	if len(specs) != 3 {
+		t.Fatalf("got %d specs, want 3", len(specs))
+	}
+
+	fileOpen, ok := specs["file-open"]
+	if !ok {
+		t.Fatal("missing spec for file-open")
+	}
+
+	if fileOpen.Description != "Open file." {
+		t.Errorf("file-open.Description = %q, want %q", fileOpen.Description, "Open file.")
+	}
+
+	attr, ok := specs["object-set-attribute"]
+	if !ok {
+		t.Fatal("missing spec for object-set-attribute")
+	}
+
+	wantArgType := "object-set-attribute:attribute name, attribute value;"
+	if attr.ArgType != wantArgType {
+		t.Errorf("object-set-attribute.ArgType = %q, want %q", attr.ArgType, wantArgType)
+	}
+}
+
+func TestActionPipelineAddValidation(t *testing.T) {
+	specs := map[string]ActionSpec{
+		"file-open":  {Name: "file-open"},
+		"export-do":  {Name: "export-do"},
+		"file-close": {Name: "file-close"},
+	}
+
+	pipeline := NewActionPipeline(specs)
+	pipeline.Add("file-open", "circle.svg")
+	pipeline.Add("export-do2")
+	pipeline.Add("file-close")
+
+	if err := pipeline.Err(); err == nil {
+		t.Fatal("expected validation error for unknown action, got nil")
+	} else if want := "unknown action `export-do2`"; err.Error() != want {
+		t.Errorf("err = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestActionPipelineAddNoValidation(t *testing.T) {
+	pipeline := NewActionPipeline(nil)
+	pipeline.Add("file-open", "circle.svg").Add("whatever-unknown-action")
+
+	if err := pipeline.Err(); err != nil {
+		t.Errorf("expected no validation without specs, got %v", err)
+	}
+
+	want := "file-open:circle.svg;whatever-unknown-action"
+	if got := pipeline.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestActionPipelineAddRaw(t *testing.T) {
+	specs := map[string]ActionSpec{"file-open": {Name: "file-open"}}
+
+	pipeline := NewActionPipeline(specs)
+	pipeline.AddRaw(FileOpen("circle.svg"))
+
+	if err := pipeline.Err(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	want := "file-open:circle.svg"
+	if got := pipeline.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	pipeline.AddRaw("export-do2")
+	if err := pipeline.Err(); err == nil {
+		t.Fatal("expected validation error for unknown raw action, got nil")
+	}
+}
@@ -0,0 +1,108 @@
+package inkscape
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// Client talks to an inkscaped daemon over a Unix socket, satisfying
+// the same Svg2Pdf/Export/RawCommands surface as Proxy so library
+// users can transparently switch between in-process and daemon-backed
+// execution.
+type Client struct {
+	socketPath string
+}
+
+// Dial creates a Client bound to the inkscaped daemon listening on
+// socketPath. Dial only verifies the socket is reachable; each call
+// opens its own short-lived connection, while the daemon keeps its own
+// warm Pool alive across calls.
+func Dial(socketPath string) (*Client, error) {
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+	conn.Close()
+
+	return &Client{socketPath: socketPath}, nil
+}
+
+func (c *Client) call(ctx context.Context, req DaemonRequest) (DaemonResponse, error) {
+	conn, err := net.Dial("unix", c.socketPath)
+	if err != nil {
+		return DaemonResponse{}, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	if err := WriteFrame(conn, req); err != nil {
+		return DaemonResponse{}, ctxErr(ctx, err)
+	}
+
+	var res DaemonResponse
+	if err := ReadFrame(conn, &res); err != nil {
+		return DaemonResponse{}, ctxErr(ctx, err)
+	}
+
+	if !res.OK {
+		return res, errors.New(res.Stderr)
+	}
+
+	return res, nil
+}
+
+// ctxErr reports ctx's own error when it is the reason conn failed,
+// so a caller that canceled ctx sees that instead of the lower-level
+// "use of closed network connection".
+func ctxErr(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	return err
+}
+
+// RawCommands send inkscape shell commands via the daemon
+func (c *Client) RawCommands(args ...string) ([]byte, error) {
+	return c.RawCommandsContext(context.Background(), args...)
+}
+
+// RawCommandsContext send inkscape shell commands that are bounded into
+// specific context via the daemon
+func (c *Client) RawCommandsContext(ctx context.Context, args ...string) ([]byte, error) {
+	res, err := c.call(ctx, DaemonRequest{Op: "raw", Args: args})
+	return []byte(res.Result), err
+}
+
+// Svg2Pdf convert svg input file to output pdf file via the daemon
+func (c *Client) Svg2Pdf(svgIn, pdfOut string) error {
+	return c.Svg2PdfContext(context.Background(), svgIn, pdfOut)
+}
+
+// Svg2PdfContext convert svg input file to output pdf file that are
+// bounded into specific context via the daemon
+func (c *Client) Svg2PdfContext(ctx context.Context, svgIn, pdfOut string) error {
+	_, err := c.call(ctx, DaemonRequest{Op: "svg2pdf", SvgIn: svgIn, Out: pdfOut})
+	return err
+}
+
+// Export converts svgIn to out via the daemon using opts
+func (c *Client) Export(ctx context.Context, svgIn, out string, opts ExportOptions) error {
+	_, err := c.call(ctx, DaemonRequest{Op: "export", SvgIn: svgIn, Out: out, Export: &opts})
+	return err
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/galihrivanto/go-inkscape"
+)
+
+var (
+	socketPath  string
+	poolSize    int
+	idleTimeout time.Duration
+)
+
+func handleErr(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func main() {
+	flag.StringVar(&socketPath, "socket", "/tmp/inkscaped.sock", "unix socket to listen on")
+	flag.IntVar(&poolSize, "pool-size", 2, "number of warm inkscape shells to keep in the pool")
+	flag.DurationVar(&idleTimeout, "idle-timeout", 5*time.Minute, "quit inkscape workers after this long without a request, 0 disables")
+	flag.Parse()
+
+	pool := inkscape.NewPool(inkscape.PoolSize(poolSize))
+	handleErr(pool.Run())
+
+	os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	handleErr(err)
+
+	d := &daemon{pool: pool, idleTimeout: idleTimeout}
+	d.touch()
+
+	go d.watchIdle(listener)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		d.shutdown(listener)
+	}()
+
+	fmt.Println("inkscaped listening on", socketPath)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			// listener closed during shutdown
+			break
+		}
+
+		d.wg.Add(1)
+		go d.handle(conn)
+	}
+
+	// shutdown blocks here while another goroutine's shutdown is still
+	// draining, so main never returns before the pool is closed
+	d.shutdown(listener)
+}
+
+// daemon serves inkscape.DaemonRequest RPCs against a warm
+// inkscape.Pool over a Unix socket, so short-lived CLI callers amortize
+// the 1-3 second cost of starting an inkscape shell across requests.
+type daemon struct {
+	pool *inkscape.Pool
+
+	mu           sync.Mutex
+	lastActivity time.Time
+	idleTimeout  time.Duration
+
+	wg           sync.WaitGroup
+	shutdownOnce sync.Once
+}
+
+func (d *daemon) touch() {
+	d.mu.Lock()
+	d.lastActivity = time.Now()
+	d.mu.Unlock()
+}
+
+func (d *daemon) idleFor() time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return time.Since(d.lastActivity)
+}
+
+// watchIdle shuts the daemon down after idleTimeout has elapsed with
+// no requests, so a forgotten daemon doesn't keep inkscape processes
+// running forever.
+func (d *daemon) watchIdle(listener net.Listener) {
+	if d.idleTimeout <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if d.idleFor() >= d.idleTimeout {
+			fmt.Println("idle timeout reached, shutting down")
+			d.shutdown(listener)
+			return
+		}
+	}
+}
+
+// shutdown stops accepting new connections, drains inflight requests,
+// then stops the pool and exits. SIGTERM and the idle-timeout ticker
+// can both call shutdown concurrently; shutdownOnce ensures the pool is
+// only closed once.
+func (d *daemon) shutdown(listener net.Listener) {
+	d.shutdownOnce.Do(func() {
+		listener.Close()
+		d.wg.Wait()
+		d.pool.Close()
+		os.Remove(socketPath)
+		os.Exit(0)
+	})
+}
+
+// requestReadTimeout bounds how long handle waits for a client to
+// finish writing its request, so a connected-but-silent client can't
+// hang graceful shutdown's wait for inflight requests to drain.
+const requestReadTimeout = 30 * time.Second
+
+func (d *daemon) handle(conn net.Conn) {
+	defer d.wg.Done()
+	defer conn.Close()
+
+	d.touch()
+
+	conn.SetReadDeadline(time.Now().Add(requestReadTimeout))
+
+	var req inkscape.DaemonRequest
+	if err := inkscape.ReadFrame(conn, &req); err != nil {
+		return
+	}
+
+	conn.SetReadDeadline(time.Time{})
+
+	start := time.Now()
+	res := d.dispatch(req)
+	res.DurationMs = time.Since(start).Milliseconds()
+
+	inkscape.WriteFrame(conn, res)
+}
+
+func (d *daemon) dispatch(req inkscape.DaemonRequest) inkscape.DaemonResponse {
+	switch req.Op {
+	case "raw":
+		out, err := d.pool.RawCommands(req.Args...)
+		return toResponse(string(out), err)
+
+	case "svg2pdf":
+		err := d.pool.Svg2Pdf(req.SvgIn, req.Out)
+		return toResponse("", err)
+
+	case "export":
+		opts := inkscape.ExportOptions{}
+		if req.Export != nil {
+			opts = *req.Export
+		}
+
+		err := d.pool.Export(context.Background(), req.SvgIn, req.Out, opts)
+		return toResponse("", err)
+
+	default:
+		return toResponse("", fmt.Errorf("unknown op `%s`", req.Op))
+	}
+}
+
+func toResponse(result string, err error) inkscape.DaemonResponse {
+	if err != nil {
+		return inkscape.DaemonResponse{Stderr: err.Error()}
+	}
+
+	return inkscape.DaemonResponse{OK: true, Result: result}
+}
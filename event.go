@@ -0,0 +1,44 @@
+package inkscape
+
+import "time"
+
+// EventType enumerates the stage of a command's lifecycle an Event
+// reports on.
+type EventType int
+
+// event types emitted on Proxy.Events()
+const (
+	EventStart EventType = iota
+	EventProgress
+	EventWarning
+	EventError
+	EventDone
+)
+
+// String implements fmt.Stringer
+func (t EventType) String() string {
+	switch t {
+	case EventStart:
+		return "start"
+	case EventProgress:
+		return "progress"
+	case EventWarning:
+		return "warning"
+	case EventError:
+		return "error"
+	case EventDone:
+		return "done"
+	default:
+		return "unknown"
+	}
+}
+
+// Event reports the progress of a command sent to the running
+// inkscape instance, so that callers can render progress for a batch
+// of commands or log per-command latency without parsing raw stderr.
+type Event struct {
+	Type      EventType
+	Command   string
+	Bytes     int
+	Timestamp time.Time
+}
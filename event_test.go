@@ -0,0 +1,63 @@
+package inkscape
+
+import "testing"
+
+func TestEventTypeString(t *testing.T) {
+	cases := map[EventType]string{
+		EventStart:    "start",
+		EventProgress: "progress",
+		EventWarning:  "warning",
+		EventError:    "error",
+		EventDone:     "done",
+		EventType(99): "unknown",
+	}
+
+	for typ, want := range cases {
+		if got := typ.String(); got != want {
+			t.Errorf("EventType(%d).String() = %q, want %q", typ, got, want)
+		}
+	}
+}
+
+func TestEmitEventSequencing(t *testing.T) {
+	p := &Proxy{events: make(chan Event, 4)}
+
+	p.emitEvent(EventStart, "file-open:a.svg", 0)
+	p.emitEvent(EventProgress, "file-open:a.svg", 12)
+	p.emitEvent(EventWarning, "file-open:a.svg", 7)
+	p.emitEvent(EventDone, "file-open:a.svg", 0)
+
+	want := []EventType{EventStart, EventProgress, EventWarning, EventDone}
+	for i, wantType := range want {
+		select {
+		case got := <-p.events:
+			if got.Type != wantType {
+				t.Errorf("event %d: Type = %v, want %v", i, got.Type, wantType)
+			}
+			if got.Command != "file-open:a.svg" {
+				t.Errorf("event %d: Command = %q, want %q", i, got.Command, "file-open:a.svg")
+			}
+		default:
+			t.Fatalf("event %d: channel empty, want %v", i, wantType)
+		}
+	}
+}
+
+func TestEmitEventDropsWhenFull(t *testing.T) {
+	p := &Proxy{events: make(chan Event, 1)}
+
+	p.emitEvent(EventStart, "cmd1", 0)
+	// the channel is now full; this emit must be dropped rather than block
+	p.emitEvent(EventDone, "cmd2", 0)
+
+	got := <-p.events
+	if got.Command != "cmd1" || got.Type != EventStart {
+		t.Errorf("got %+v, want the first event (cmd1/EventStart) to survive", got)
+	}
+
+	select {
+	case extra := <-p.events:
+		t.Errorf("expected channel to be empty after the surviving event, got %+v", extra)
+	default:
+	}
+}
@@ -0,0 +1,270 @@
+package inkscape
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"image/color"
+)
+
+// ExportFormat enumerates the output formats Proxy.Export supports,
+// mapped to inkscape's `export-type` action values.
+type ExportFormat string
+
+// supported export formats
+const (
+	FormatPDF      ExportFormat = "pdf"
+	FormatPNG      ExportFormat = "png"
+	FormatPS       ExportFormat = "ps"
+	FormatEPS      ExportFormat = "eps"
+	FormatEMF      ExportFormat = "emf"
+	FormatWMF      ExportFormat = "wmf"
+	FormatPlainSVG ExportFormat = "svg"
+)
+
+// AreaMode selects the region of the document Proxy.Export exports.
+type AreaMode int
+
+// supported area modes
+const (
+	AreaPage AreaMode = iota
+	AreaDrawing
+	AreaCustom
+)
+
+// Rect is a custom export area expressed in document units,
+// (X0,Y0)-(X1,Y1).
+type Rect struct {
+	X0, Y0, X1, Y1 int
+}
+
+// ExportOptions configures Proxy.Export.
+type ExportOptions struct {
+	// Format selects the output file type. Required.
+	Format ExportFormat
+
+	// DPI overrides the export resolution, in dots per inch.
+	DPI float64
+
+	// Area selects which region of the document is exported. Defaults
+	// to AreaPage.
+	Area AreaMode
+
+	// CustomArea is used when Area is AreaCustom.
+	CustomArea Rect
+
+	// IDs restricts the export to the given object ids. When empty,
+	// the whole page or drawing is exported, per Area.
+	IDs []string
+
+	// IDOnly exports only the IDs' geometry, without the page canvas.
+	IDOnly bool
+
+	// Background overrides the export background color.
+	Background color.Color
+
+	// BackgroundOpacity overrides the export background opacity, 0-1.
+	// Nil leaves inkscape's default opacity untouched; a non-nil zero
+	// requests a fully transparent background.
+	BackgroundOpacity *float64
+
+	// TextToPath converts text objects to paths on export.
+	TextToPath bool
+
+	// LatexSidecar additionally exports a LaTeX overlay file.
+	LatexSidecar bool
+
+	// PdfVersion overrides the PDF version, e.g. "1.5". Only applies
+	// when Format is FormatPDF.
+	PdfVersion string
+
+	// PsLevel overrides the PostScript level. Only applies when Format
+	// is FormatPS or FormatEPS.
+	PsLevel int
+}
+
+// exportOptionsJSON mirrors ExportOptions for JSON encoding, replacing
+// Background with a JSON-safe hex string since color.Color is an
+// interface encoding/json cannot unmarshal into. It's used by
+// ExportOptions.MarshalJSON/UnmarshalJSON so ExportOptions (and the
+// DaemonRequest that embeds it) round-trip over inkscaped's Unix
+// socket protocol.
+type exportOptionsJSON struct {
+	Format            ExportFormat
+	DPI               float64
+	Area              AreaMode
+	CustomArea        Rect
+	IDs               []string
+	IDOnly            bool
+	Background        string `json:",omitempty"`
+	BackgroundOpacity *float64
+	TextToPath        bool
+	LatexSidecar      bool
+	PdfVersion        string
+	PsLevel           int
+}
+
+// MarshalJSON implements json.Marshaler, encoding Background as the
+// same "#rrggbb" hex string ExportBackground sends to inkscape.
+func (o ExportOptions) MarshalJSON() ([]byte, error) {
+	alias := exportOptionsJSON{
+		Format:            o.Format,
+		DPI:               o.DPI,
+		Area:              o.Area,
+		CustomArea:        o.CustomArea,
+		IDs:               o.IDs,
+		IDOnly:            o.IDOnly,
+		BackgroundOpacity: o.BackgroundOpacity,
+		TextToPath:        o.TextToPath,
+		LatexSidecar:      o.LatexSidecar,
+		PdfVersion:        o.PdfVersion,
+		PsLevel:           o.PsLevel,
+	}
+
+	if o.Background != nil {
+		nrgba := color.NRGBAModel.Convert(o.Background).(color.NRGBA)
+		alias.Background = fmt.Sprintf("#%02x%02x%02x", nrgba.R, nrgba.G, nrgba.B)
+	}
+
+	return json.Marshal(alias)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the inverse of MarshalJSON.
+func (o *ExportOptions) UnmarshalJSON(data []byte) error {
+	var alias exportOptionsJSON
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+
+	*o = ExportOptions{
+		Format:            alias.Format,
+		DPI:               alias.DPI,
+		Area:              alias.Area,
+		CustomArea:        alias.CustomArea,
+		IDs:               alias.IDs,
+		IDOnly:            alias.IDOnly,
+		BackgroundOpacity: alias.BackgroundOpacity,
+		TextToPath:        alias.TextToPath,
+		LatexSidecar:      alias.LatexSidecar,
+		PdfVersion:        alias.PdfVersion,
+		PsLevel:           alias.PsLevel,
+	}
+
+	if alias.Background != "" {
+		c, err := parseHexColor(alias.Background)
+		if err != nil {
+			return fmt.Errorf("export options: background: %w", err)
+		}
+		o.Background = c
+	}
+
+	return nil
+}
+
+// parseHexColor parses a "#rrggbb" string into an opaque color.NRGBA.
+func parseHexColor(s string) (color.NRGBA, error) {
+	var r, g, b uint8
+	if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return color.NRGBA{}, fmt.Errorf("invalid hex color %q", s)
+	}
+
+	return color.NRGBA{R: r, G: g, B: b, A: 0xff}, nil
+}
+
+// buildExportPipeline composes the export-* action pipeline for opts,
+// validated against specs (pass nil to skip validation). Factored out
+// of Export so the composition can be unit tested without a running
+// inkscape instance.
+func buildExportPipeline(specs map[string]ActionSpec, svgIn, out string, opts ExportOptions) *ActionPipeline {
+	pipeline := NewActionPipeline(specs)
+
+	pipeline.AddRaw(FileOpen(svgIn))
+	pipeline.AddRaw(ExportFileName(out))
+	pipeline.AddRaw(ExportType(opts.Format))
+
+	if opts.DPI > 0 {
+		pipeline.AddRaw(ExportDPI(opts.DPI))
+	}
+
+	switch opts.Area {
+	case AreaDrawing:
+		pipeline.AddRaw(ExportAreaDrawing())
+	case AreaCustom:
+		pipeline.AddRaw(ExportArea(opts.CustomArea.X0, opts.CustomArea.Y0, opts.CustomArea.X1, opts.CustomArea.Y1))
+	default:
+		pipeline.AddRaw(ExportAreaPage())
+	}
+
+	if len(opts.IDs) > 0 {
+		pipeline.AddRaw(ExportID(opts.IDs...))
+
+		if opts.IDOnly {
+			pipeline.AddRaw(ExportIDOnly())
+		}
+	}
+
+	if opts.Background != nil {
+		pipeline.AddRaw(ExportBackground(opts.Background))
+	}
+
+	if opts.BackgroundOpacity != nil {
+		pipeline.AddRaw(ExportBackgroundOpacity(*opts.BackgroundOpacity))
+	}
+
+	if opts.TextToPath {
+		pipeline.AddRaw(ExportTextToPath())
+	}
+
+	if opts.LatexSidecar {
+		pipeline.AddRaw(ExportLatex())
+	}
+
+	if opts.Format == FormatPlainSVG {
+		pipeline.AddRaw(ExportPlainSVG())
+	}
+
+	if opts.PdfVersion != "" && opts.Format == FormatPDF {
+		pipeline.AddRaw(ExportPdfVersion(opts.PdfVersion))
+	}
+
+	if opts.PsLevel > 0 && (opts.Format == FormatPS || opts.Format == FormatEPS) {
+		pipeline.AddRaw(ExportPsLevel(opts.PsLevel))
+	}
+
+	pipeline.AddRaw(ExportDo())
+	pipeline.AddRaw(FileClose())
+
+	return pipeline
+}
+
+// Export converts svgIn to out using the options described by opts,
+// composing the right export-* action pipeline for inkscape's shell
+// and validating it against the actions discovered by
+// Proxy.DiscoverActions, if any.
+func (p *Proxy) Export(ctx context.Context, svgIn, out string, opts ExportOptions) error {
+	pipeline := buildExportPipeline(p.actions, svgIn, out, opts)
+
+	res, err := p.SendPipeline(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+
+	p.debug("result", string(res))
+
+	return nil
+}
+
+// Svg2Png convert svg input file to output png file
+func (p *Proxy) Svg2Png(svgIn, pngOut string) error {
+	return p.Export(context.Background(), svgIn, pngOut, ExportOptions{Format: FormatPNG})
+}
+
+// Svg2Eps convert svg input file to output eps file
+func (p *Proxy) Svg2Eps(svgIn, epsOut string) error {
+	return p.Export(context.Background(), svgIn, epsOut, ExportOptions{Format: FormatEPS})
+}
+
+// Svg2PlainSvg convert svg input file to a plain (non-inkscape) svg file
+func (p *Proxy) Svg2PlainSvg(svgIn, svgOut string) error {
+	return p.Export(context.Background(), svgIn, svgOut, ExportOptions{Format: FormatPlainSVG})
+}
@@ -0,0 +1,67 @@
+package inkscape
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestBuildExportPipelineDefaults(t *testing.T) {
+	pipeline := buildExportPipeline(nil, "in.svg", "out.pdf", ExportOptions{Format: FormatPDF})
+
+	want := "file-open:in.svg;export-filename:out.pdf;export-type:pdf;export-area-page;export-do;file-close"
+	if got := pipeline.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	if err := pipeline.Err(); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+}
+
+func TestBuildExportPipelineOptions(t *testing.T) {
+	opacity := 0.0
+	opts := ExportOptions{
+		Format:            FormatPNG,
+		DPI:               300,
+		Area:              AreaCustom,
+		CustomArea:        Rect{X0: 0, Y0: 0, X1: 10, Y1: 20},
+		IDs:               []string{"layer1", "layer2"},
+		IDOnly:            true,
+		Background:        color.White,
+		BackgroundOpacity: &opacity,
+		TextToPath:        true,
+	}
+
+	pipeline := buildExportPipeline(nil, "in.svg", "out.png", opts)
+
+	want := "file-open:in.svg;export-filename:out.png;export-type:png;export-dpi:300;" +
+		"export-area:0:0:10:20;export-id:layer1,layer2;export-id-only;" +
+		"export-background:#ffffff;export-background-opacity:0;export-text-to-path;export-do;file-close"
+	if got := pipeline.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestBuildExportPipelineBackgroundOpacityUnset(t *testing.T) {
+	pipeline := buildExportPipeline(nil, "in.svg", "out.png", ExportOptions{Format: FormatPNG})
+
+	if got := pipeline.String(); strings.Contains(got, "export-background-opacity") {
+		t.Errorf("String() = %q, should not include export-background-opacity when unset", got)
+	}
+}
+
+func TestBuildExportPipelineValidation(t *testing.T) {
+	specs := map[string]ActionSpec{
+		"file-open":        {Name: "file-open"},
+		"export-filename":  {Name: "export-filename"},
+		"export-type":      {Name: "export-type"},
+		"export-area-page": {Name: "export-area-page"},
+	}
+
+	pipeline := buildExportPipeline(specs, "in.svg", "out.pdf", ExportOptions{Format: FormatPDF})
+
+	if err := pipeline.Err(); err == nil {
+		t.Fatal("expected validation error for unknown export-do/file-close actions, got nil")
+	}
+}
@@ -0,0 +1,57 @@
+package inkscape
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// scanFrames reads r using a bufio.Scanner configured with
+// splitFrames and pushes each framed line onto out, closing out once r
+// reaches EOF (i.e. the underlying process closes its stream).
+// Framing happens at the byte-stream level, via bufio.Scanner's
+// internal buffering, so that output spanning multiple Write calls
+// from inkscape, or several lines within a single Write call, are
+// reassembled identically instead of depending on how the kernel
+// happened to chunk the writes.
+func scanFrames(r io.Reader, out chan<- []byte) {
+	defer close(out)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	scanner.Split(splitFrames)
+
+	for scanner.Scan() {
+		line := append([]byte(nil), scanner.Bytes()...)
+		out <- line
+	}
+}
+
+// splitFrames is a bufio.SplitFunc that frames inkscape shell output
+// into discrete lines. It tolerates CRLF line endings (Inkscape 1.3 on
+// Windows) and recognizes the interactive prompt even when it
+// terminates the buffer without a trailing newline, since inkscape
+// does not print one after its prompt.
+func splitFrames(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, bytes.TrimRight(data[:i], "\r"), nil
+	}
+
+	// the prompt is never followed by a newline, so flush it as soon
+	// as it appears at the end of the buffer instead of waiting for
+	// more input that will never come
+	if isPrompt(data) {
+		return len(data), data, nil
+	}
+
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	// request more data
+	return 0, nil, nil
+}
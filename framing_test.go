@@ -0,0 +1,67 @@
+package inkscape
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+// TestScanFramesMidBufferPrompt replays a captured Inkscape 1.2
+// interaction where the shell prompt is written in the same syscall as
+// the preceding output, without a trailing newline, and is prefixed by
+// a warning on its own line. scanFrames must still frame the output
+// line and the prompt separately instead of blocking forever waiting
+// for a newline that will never come.
+func TestScanFramesMidBufferPrompt(t *testing.T) {
+	r, w := io.Pipe()
+
+	out := make(chan []byte)
+	go scanFrames(r, out)
+
+	go func() {
+		// single write: the output lines and the following prompt
+		// arrive in the same syscall/read, with the prompt carrying
+		// no trailing newline
+		w.Write([]byte("Document saved.\nWARNING: some warning\n> "))
+		w.Close()
+	}()
+
+	want := []string{"Document saved.", "WARNING: some warning", "> "}
+
+	for i, w := range want {
+		select {
+		case got, ok := <-out:
+			if !ok {
+				t.Fatalf("frame %d: channel closed early", i)
+			}
+
+			if string(got) != w {
+				t.Errorf("frame %d: got %q, want %q", i, got, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("frame %d: timed out waiting for %q", i, w)
+		}
+	}
+
+	if _, ok := <-out; ok {
+		t.Error("expected channel to close after all frames consumed")
+	}
+}
+
+func TestIsPrompt(t *testing.T) {
+	cases := map[string]bool{
+		">":               true,
+		"> ":              true,
+		"> \r":            true,
+		"WARNING: blah >": true,
+		"":                false,
+		"some output":     false,
+		"1 + 1 = 2":       false,
+	}
+
+	for input, want := range cases {
+		if got := isPrompt([]byte(input)); got != want {
+			t.Errorf("isPrompt(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
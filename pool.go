@@ -0,0 +1,180 @@
+package inkscape
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// poolOptions configure a Pool
+type poolOptions struct {
+	size       int
+	workerOpts []Option
+}
+
+// PoolOption define method to modify pool config options
+type PoolOption func(o *poolOptions)
+
+// PoolSize sets the number of concurrent inkscape shell workers in the
+// pool. Defaults to 1.
+func PoolSize(n int) PoolOption {
+	return func(o *poolOptions) {
+		o.size = n
+	}
+}
+
+// WorkerOptions forwards Options to every worker Proxy in the pool.
+func WorkerOptions(opts ...Option) PoolOption {
+	return func(o *poolOptions) {
+		o.workerOpts = append(o.workerOpts, opts...)
+	}
+}
+
+// WorkerStats reports a single worker's queue depth and cumulative
+// command count, as returned by Pool.Stats.
+type WorkerStats struct {
+	QueueDepth int
+	Commands   uint64
+}
+
+// Pool runs a fixed number of inkscape shells in background and
+// dispatches commands across them round-robin, so independent
+// conversions run in parallel instead of serializing through a single
+// shell. Each worker is an independent Proxy, so a crash in one worker
+// is retried independently (via runner.RunWithRetry, same as Proxy)
+// without affecting the others. Pool implements the same Svg2Pdf and
+// RawCommands surface as Proxy, so it is a drop-in replacement.
+type Pool struct {
+	workers []*Proxy
+	counts  []uint64
+	next    uint32
+}
+
+// NewPool create new pool of inkscape proxy instances
+func NewPool(opts ...PoolOption) *Pool {
+	// default value
+	options := poolOptions{
+		size: 1,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.size < 1 {
+		options.size = 1
+	}
+
+	pool := &Pool{
+		workers: make([]*Proxy, options.size),
+		counts:  make([]uint64, options.size),
+	}
+
+	for i := range pool.workers {
+		pool.workers[i] = NewProxy(options.workerOpts...)
+	}
+
+	return pool
+}
+
+// Run starts every worker's inkscape shell
+func (p *Pool) Run(args ...string) error {
+	for i, worker := range p.workers {
+		if err := worker.Run(args...); err != nil {
+			// stop workers already started so a partial failure
+			// doesn't leak their inkscape child processes
+			for _, started := range p.workers[:i] {
+				started.Close()
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close stops every worker's inkscape shell
+func (p *Pool) Close() error {
+	var err error
+	for _, worker := range p.workers {
+		if closeErr := worker.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// pick returns the next worker to dispatch to, round-robin
+func (p *Pool) pick() (int, *Proxy) {
+	i := int(atomic.AddUint32(&p.next, 1)-1) % len(p.workers)
+	return i, p.workers[i]
+}
+
+// RawCommands send inkscape shell commands to the next available worker
+func (p *Pool) RawCommands(args ...string) ([]byte, error) {
+	return p.RawCommandsContext(context.Background(), args...)
+}
+
+// RawCommandsContext send inkscape shell commands that are bounded into
+// specific context to the next available worker
+func (p *Pool) RawCommandsContext(ctx context.Context, args ...string) ([]byte, error) {
+	i, worker := p.pick()
+	atomic.AddUint64(&p.counts[i], 1)
+
+	return worker.RawCommandsContext(ctx, args...)
+}
+
+// Svg2Pdf convert svg input file to output pdf file using the next
+// available worker
+func (p *Pool) Svg2Pdf(svgIn, pdfOut string) error {
+	return p.Svg2PdfContext(context.Background(), svgIn, pdfOut)
+}
+
+// Svg2PdfContext convert svg input file to output pdf file that are
+// bounded into specific context, using the next available worker
+func (p *Pool) Svg2PdfContext(ctx context.Context, svgIn, pdfOut string) error {
+	i, worker := p.pick()
+	atomic.AddUint64(&p.counts[i], 1)
+
+	return worker.Svg2PdfContext(ctx, svgIn, pdfOut)
+}
+
+// Export converts svgIn to out using the next available worker
+func (p *Pool) Export(ctx context.Context, svgIn, out string, opts ExportOptions) error {
+	i, worker := p.pick()
+	atomic.AddUint64(&p.counts[i], 1)
+
+	return worker.Export(ctx, svgIn, out, opts)
+}
+
+// Svg2Png convert svg input file to output png file using the next
+// available worker
+func (p *Pool) Svg2Png(svgIn, pngOut string) error {
+	return p.Export(context.Background(), svgIn, pngOut, ExportOptions{Format: FormatPNG})
+}
+
+// Svg2Eps convert svg input file to output eps file using the next
+// available worker
+func (p *Pool) Svg2Eps(svgIn, epsOut string) error {
+	return p.Export(context.Background(), svgIn, epsOut, ExportOptions{Format: FormatEPS})
+}
+
+// Svg2PlainSvg convert svg input file to a plain (non-inkscape) svg
+// file using the next available worker
+func (p *Pool) Svg2PlainSvg(svgIn, svgOut string) error {
+	return p.Export(context.Background(), svgIn, svgOut, ExportOptions{Format: FormatPlainSVG})
+}
+
+// Stats returns per-worker queue depth and cumulative command counts.
+func (p *Pool) Stats() []WorkerStats {
+	stats := make([]WorkerStats, len(p.workers))
+	for i, worker := range p.workers {
+		stats[i] = WorkerStats{
+			QueueDepth: len(worker.requestQueue),
+			Commands:   atomic.LoadUint64(&p.counts[i]),
+		}
+	}
+
+	return stats
+}
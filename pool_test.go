@@ -0,0 +1,64 @@
+package inkscape
+
+import "testing"
+
+// TestPoolPickRoundRobin verifies pick cycles through every worker in
+// order before repeating, without needing a running inkscape shell.
+func TestPoolPickRoundRobin(t *testing.T) {
+	pool := NewPool(PoolSize(3))
+
+	var got []int
+	for i := 0; i < 7; i++ {
+		idx, worker := pool.pick()
+		if worker != pool.workers[idx] {
+			t.Fatalf("pick returned worker for index %d that doesn't match pool.workers[%d]", idx, idx)
+		}
+
+		got = append(got, idx)
+	}
+
+	want := []int{0, 1, 2, 0, 1, 2, 0}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("pick() call %d = %d, want %d (got sequence %v)", i, got[i], want[i], got)
+			break
+		}
+	}
+}
+
+// TestPoolStats verifies Stats reports per-worker command counts as
+// dispatched by pick, and an empty queue depth before any worker is
+// started.
+func TestPoolStats(t *testing.T) {
+	pool := NewPool(PoolSize(2))
+
+	for i := 0; i < 5; i++ {
+		idx, _ := pool.pick()
+		pool.counts[idx]++
+	}
+
+	stats := pool.Stats()
+	if len(stats) != 2 {
+		t.Fatalf("got %d worker stats, want 2", len(stats))
+	}
+
+	if stats[0].Commands != 3 || stats[1].Commands != 2 {
+		t.Errorf("got commands %d,%d, want 3,2", stats[0].Commands, stats[1].Commands)
+	}
+
+	for i, s := range stats {
+		if s.QueueDepth != 0 {
+			t.Errorf("worker %d: QueueDepth = %d, want 0 before Run", i, s.QueueDepth)
+		}
+	}
+}
+
+// TestPoolSizeDefaultsToOne verifies an invalid PoolSize falls back to
+// a single worker instead of an empty, unusable pool.
+func TestPoolSizeDefaultsToOne(t *testing.T) {
+	pool := NewPool(PoolSize(0))
+
+	if len(pool.workers) != 1 {
+		t.Fatalf("got %d workers, want 1", len(pool.workers))
+	}
+}
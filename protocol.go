@@ -0,0 +1,73 @@
+package inkscape
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxFrameSize bounds the length prefix ReadFrame will allocate for, so
+// a malformed or malicious frame on the Unix socket can't force a
+// multi-gigabyte allocation per connection.
+const maxFrameSize = 8 * 1024 * 1024
+
+// DaemonRequest is the length-prefixed JSON request exchanged between
+// an inkscape.Client and an inkscaped daemon over its Unix socket.
+type DaemonRequest struct {
+	Op     string         `json:"op"`
+	Args   []string       `json:"args,omitempty"`
+	SvgIn  string         `json:"svgIn,omitempty"`
+	Out    string         `json:"out,omitempty"`
+	Export *ExportOptions `json:"export,omitempty"`
+}
+
+// DaemonResponse is the length-prefixed JSON response an inkscaped
+// daemon returns for a DaemonRequest.
+type DaemonResponse struct {
+	OK         bool   `json:"ok"`
+	Result     string `json:"result,omitempty"`
+	Stderr     string `json:"stderr,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// WriteFrame writes v to w as a 4-byte big-endian length prefix
+// followed by its JSON encoding.
+func WriteFrame(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(data)))
+
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+// ReadFrame reads a single length-prefixed JSON value written by
+// WriteFrame into v.
+func ReadFrame(r io.Reader, v interface{}) error {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxFrameSize {
+		return fmt.Errorf("frame size %d exceeds maximum of %d bytes", size, maxFrameSize)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,77 @@
+package inkscape
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/color"
+	"testing"
+)
+
+func TestWriteReadFrameRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+
+	req := DaemonRequest{Op: "raw", Args: []string{"file-open:a.svg"}}
+	if err := WriteFrame(&buf, req); err != nil {
+		t.Fatalf("WriteFrame: %v", err)
+	}
+
+	var got DaemonRequest
+	if err := ReadFrame(&buf, &got); err != nil {
+		t.Fatalf("ReadFrame: %v", err)
+	}
+
+	if got.Op != req.Op || len(got.Args) != 1 || got.Args[0] != req.Args[0] {
+		t.Errorf("got %+v, want %+v", got, req)
+	}
+}
+
+func TestWriteReadFrameRoundTripExportBackground(t *testing.T) {
+	var buf bytes.Buffer
+
+	opacity := 0.5
+	req := DaemonRequest{
+		Op:    "export",
+		SvgIn: "in.svg",
+		Out:   "out.png",
+		Export: &ExportOptions{
+			Format:            FormatPNG,
+			Background:        color.White,
+			BackgroundOpacity: &opacity,
+		},
+	}
+
+	if err := WriteFrame(&buf, req); err != nil {
+		t.Fatalf("WriteFrame: %v", err)
+	}
+
+	var got DaemonRequest
+	if err := ReadFrame(&buf, &got); err != nil {
+		t.Fatalf("ReadFrame: %v", err)
+	}
+
+	if got.Export == nil {
+		t.Fatal("got.Export = nil")
+	}
+
+	wantBG := ExportBackground(color.White)
+	if gotBG := ExportBackground(got.Export.Background); gotBG != wantBG {
+		t.Errorf("Background round-tripped to %q, want %q", gotBG, wantBG)
+	}
+
+	if got.Export.BackgroundOpacity == nil || *got.Export.BackgroundOpacity != 0.5 {
+		t.Errorf("BackgroundOpacity = %v, want 0.5", got.Export.BackgroundOpacity)
+	}
+}
+
+func TestReadFrameRejectsOversizedLength(t *testing.T) {
+	var buf bytes.Buffer
+
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], maxFrameSize+1)
+	buf.Write(header[:])
+
+	var v DaemonRequest
+	if err := ReadFrame(&buf, &v); err == nil {
+		t.Fatal("expected error for frame size exceeding maxFrameSize, got nil")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/galihrivanto/runner"
@@ -27,24 +28,31 @@ var (
 	ErrCommandExecCanceled = errors.New("command execution canceled")
 )
 
-// bytes.Buffer pool
-var bufferPool = NewSizedBufferPool(5, 1024*1024)
+// bytes.Buffer pool, used by RawCommandsContext so the buffer that
+// holds each command is reused across calls
+var bufferPool = newBufferPool()
 
-type chanWriter struct {
-	out chan []byte
+type sizedBufferPool struct {
+	pool sync.Pool
 }
 
-func (w *chanWriter) Write(data []byte) (int, error) {
-
-	// look like the buffer being reused internally by the exec.Command
-	// so we can't directly read the buffer in another goroutine while still being used in exec.Command goroutine
+func newBufferPool() *sizedBufferPool {
+	return &sizedBufferPool{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return new(bytes.Buffer)
+			},
+		},
+	}
+}
 
-	// copy to be written buffer and pass it into channel
-	bufferToWrite := make([]byte, len(data))
-	written := copy(bufferToWrite, data)
-	w.out <- bufferToWrite
+func (p *sizedBufferPool) Get() *bytes.Buffer {
+	return p.pool.Get().(*bytes.Buffer)
+}
 
-	return written, nil
+func (p *sizedBufferPool) Put(b *bytes.Buffer) {
+	b.Reset()
+	p.pool.Put(b)
 }
 
 // Proxy runs inkscape instance in background and
@@ -67,6 +75,28 @@ type Proxy struct {
 	// output
 	stdout chan []byte
 	stderr chan []byte
+
+	// actions discovered via DiscoverActions, keyed by action name
+	actions map[string]ActionSpec
+
+	// progress events for commands sent through this proxy
+	events chan Event
+}
+
+// Events returns a channel of progress events for commands sent
+// through this proxy. Each command is bracketed by an EventStart and
+// an EventDone (or EventError), with EventProgress/EventWarning
+// emitted for each stdout/stderr chunk received while it executes.
+func (p *Proxy) Events() <-chan Event {
+	return p.events
+}
+
+func (p *Proxy) emitEvent(typ EventType, command string, n int) {
+	select {
+	case p.events <- Event{Type: typ, Command: command, Bytes: n, Timestamp: time.Now()}:
+	default:
+		// drop event rather than block command execution when nobody is listening
+	}
 }
 
 func (p *Proxy) debug(args ...interface{}) {
@@ -88,16 +118,16 @@ func (p *Proxy) runBackground(ctx context.Context, commandPath string, vars ...s
 	cmd := exec.CommandContext(ctx, commandPath, args...)
 
 	// pipe stderr
-	stderrC := make(chan []byte)
-	defer close(stderrC)
-
-	cmd.Stderr = &chanWriter{out: stderrC}
+	stderrPipe, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
 
 	// pipe stdout
-	stdoutC := make(chan []byte)
-	defer close(stdoutC)
-
-	cmd.Stdout = &chanWriter{out: stdoutC}
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
 
 	// pipe stdin
 	stdin, err := cmd.StdinPipe()
@@ -112,17 +142,26 @@ func (p *Proxy) runBackground(ctx context.Context, commandPath string, vars ...s
 		return err
 	}
 
+	// scanFrames buffers partial lines across Read calls and frames
+	// each stdout/stderr line on its own channel message, so a prompt
+	// split across two writes from inkscape is never missed
+	stdoutC := make(chan []byte)
+	stderrC := make(chan []byte)
+
+	go scanFrames(stdoutPipe, stdoutC)
+	go scanFrames(stderrPipe, stderrC)
+
 	// make first command available
 	// after received prompt
 wait:
 	for {
-		bytesOut := <-stdoutC
-		bytesOut = bytes.TrimSpace(bytesOut)
-		parts := bytes.Split(bytesOut, []byte("\n"))
-		for _, part := range parts {
-			if isPrompt(part) {
-				break wait
-			}
+		bytesOut, ok := <-stdoutC
+		if !ok {
+			return cmd.Wait()
+		}
+
+		if isPrompt(bytesOut) {
+			break wait
 		}
 	}
 
@@ -144,7 +183,11 @@ wait:
 				p.stderr <- []byte(err.Error())
 			}
 
-		case bytesErr := <-stderrC:
+		case bytesErr, ok := <-stderrC:
+			if !ok {
+				return cmd.Wait()
+			}
+
 			if len(bytesErr) == 0 {
 				break
 			}
@@ -156,14 +199,18 @@ wait:
 				}
 			}
 
-			p.stderr <- bytes.TrimSpace(bytesErr)
+			p.stderr <- bytesErr
+
+		case bytesOut, ok := <-stdoutC:
+			if !ok {
+				return cmd.Wait()
+			}
 
-		case bytesOut := <-stdoutC:
 			if len(bytesOut) == 0 {
 				break
 			}
 
-			p.stdout <- bytes.TrimSpace(bytesOut)
+			p.stdout <- bytesOut
 		}
 	}
 }
@@ -206,6 +253,7 @@ func (p *Proxy) Close() error {
 	close(p.requestQueue)
 	close(p.stderr)
 	close(p.stdout)
+	close(p.events)
 
 	return err
 }
@@ -226,6 +274,9 @@ func (p *Proxy) sendCommand(ctx context.Context, b []byte, waitPrompt ...bool) (
 
 	p.debug("send command to stdin ", string(b))
 
+	command := string(bytes.TrimSpace(b))
+	p.emitEvent(EventStart, command, len(b))
+
 	// drain old err and out
 	drain(p.stderr)
 	drain(p.stdout)
@@ -245,6 +296,7 @@ func (p *Proxy) sendCommand(ctx context.Context, b []byte, waitPrompt ...bool) (
 	// immediate return
 	if !wait {
 		<-time.After(time.Second)
+		p.emitEvent(EventDone, command, 0)
 		return []byte{}, nil
 	}
 
@@ -253,18 +305,18 @@ waitLoop:
 		select {
 		// wait till context canceled, early return
 		case <-ctx.Done():
+			p.emitEvent(EventError, command, len(output))
 			return output, ErrCommandExecCanceled
 		// wait until received prompt
 		case bytesOut := <-p.stdout:
 			p.debug(string(bytesOut))
-			parts := bytes.Split(bytesOut, []byte("\n"))
-			for _, part := range parts {
-				if isPrompt(part) {
-					break waitLoop
-				}
+			if isPrompt(bytesOut) {
+				break waitLoop
 			}
 
 			output = append(output, bytesOut...)
+			output = append(output, '\n')
+			p.emitEvent(EventProgress, command, len(bytesOut))
 		}
 	}
 
@@ -276,15 +328,56 @@ errLoop:
 			if len(bytesErr) > 0 {
 				p.debug(string(bytesErr))
 				err = fmt.Errorf("%s", string(bytesErr))
+				p.emitEvent(EventWarning, command, len(bytesErr))
 			}
 		default:
 			break errLoop
 		}
 	}
 
+	if err != nil {
+		p.emitEvent(EventError, command, len(output))
+	} else {
+		p.emitEvent(EventDone, command, len(output))
+	}
+
 	return output, err
 }
 
+// DiscoverActions queries the running inkscape instance for its
+// available actions via `action-list` and caches the result so that
+// NewPipeline can validate actions before sending them. It should be
+// called once after Run, and targets Inkscape 1.0 through 1.3, all of
+// which implement `action-list`.
+func (p *Proxy) DiscoverActions(ctx context.Context) (map[string]ActionSpec, error) {
+	res, err := p.RawCommandsContext(ctx, "action-list")
+	if err != nil {
+		return nil, err
+	}
+
+	p.actions = parseActionList(res)
+
+	return p.actions, nil
+}
+
+// NewPipeline creates an ActionPipeline validated against the actions
+// discovered by DiscoverActions. If DiscoverActions has not been
+// called yet, the pipeline is created without validation.
+func (p *Proxy) NewPipeline() *ActionPipeline {
+	return NewActionPipeline(p.actions)
+}
+
+// SendPipeline serializes and sends an ActionPipeline, failing fast
+// with the pipeline's validation error, if any, instead of sending an
+// unknown action to inkscape.
+func (p *Proxy) SendPipeline(ctx context.Context, pipeline *ActionPipeline) ([]byte, error) {
+	if err := pipeline.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.sendCommand(ctx, []byte(pipeline.String()))
+}
+
 // RawCommands send inkscape shell commands
 func (p *Proxy) RawCommands(args ...string) ([]byte, error) {
 	return p.RawCommandsContext(context.Background(), args...)
@@ -343,6 +436,7 @@ func NewProxy(opts ...Option) *Proxy {
 		options: options,
 		stdout:  make(chan []byte, 100),
 		stderr:  make(chan []byte, 100),
+		events:  make(chan Event, 100),
 		logger:  log.New(os.Stdout, "[debug]", log.Lshortfile),
 
 		// limit request to one request at time
@@ -351,8 +445,15 @@ func NewProxy(opts ...Option) *Proxy {
 	}
 }
 
+// isPrompt reports whether data is inkscape's interactive shell
+// prompt. Inkscape 1.0/1.1 print a bare ">", while 1.2+ prints "> "
+// (trailing space), sometimes with a warning preceding it on the same
+// line, so a line is considered a prompt if it ends with ">" once
+// trailing whitespace (including a trailing "\r" on Windows) is
+// stripped.
 func isPrompt(data []byte) bool {
-	return bytes.Equal(data, []byte(">"))
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && trimmed[len(trimmed)-1] == '>'
 }
 
 func drain(c chan []byte) {
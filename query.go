@@ -0,0 +1,172 @@
+package inkscape
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Unit is a unit of length understood by ConvertUnit.
+type Unit string
+
+// units accepted by ConvertUnit. Px matches inkscape's query-all output,
+// which reports user units (px) regardless of the document's display
+// unit.
+const (
+	UnitPx Unit = "px"
+	UnitIn Unit = "in"
+	UnitMM Unit = "mm"
+	UnitCM Unit = "cm"
+	UnitPt Unit = "pt"
+	UnitPc Unit = "pc"
+)
+
+// pxPerUnit is the number of px in one of each Unit, using the CSS
+// reference pixel (96px = 1in).
+var pxPerUnit = map[Unit]float64{
+	UnitPx: 1,
+	UnitIn: 96,
+	UnitMM: 96 / 25.4,
+	UnitCM: 96 / 2.54,
+	UnitPt: 96.0 / 72,
+	UnitPc: 16,
+}
+
+// ConvertUnit converts value from one Unit to another. Unknown units are
+// treated as UnitPx.
+func ConvertUnit(value float64, from, to Unit) float64 {
+	fromPx, ok := pxPerUnit[from]
+	if !ok {
+		fromPx = pxPerUnit[UnitPx]
+	}
+
+	toPx, ok := pxPerUnit[to]
+	if !ok {
+		toPx = pxPerUnit[UnitPx]
+	}
+
+	return value * fromPx / toPx
+}
+
+// ObjectBounds describes the geometry of a single SVG object as
+// reported by inkscape's query-all action, in px (see ConvertUnit to
+// convert to another unit).
+type ObjectBounds struct {
+	ID     string
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+// ObjectInfo describes a single object returned by select-list.
+type ObjectInfo struct {
+	ID string
+}
+
+// QueryAll opens file and returns the bounds of every object in the
+// document, parsed from the rows inkscape's query-all action prints.
+func (p *Proxy) QueryAll(ctx context.Context, file string) ([]ObjectBounds, error) {
+	pipeline := p.NewPipeline()
+	pipeline.AddRaw(FileOpen(file))
+	pipeline.AddRaw(QueryAll())
+	pipeline.AddRaw(FileClose())
+
+	res, err := p.SendPipeline(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseObjectBounds(res), nil
+}
+
+// QueryByID opens file, selects the object with the given id and
+// returns its bounds.
+func (p *Proxy) QueryByID(ctx context.Context, file, id string) (ObjectBounds, error) {
+	pipeline := p.NewPipeline()
+	pipeline.AddRaw(FileOpen(file))
+	pipeline.AddRaw(SelectByID(id))
+	pipeline.AddRaw(QueryAll())
+	pipeline.AddRaw(FileClose())
+
+	res, err := p.SendPipeline(ctx, pipeline)
+	if err != nil {
+		return ObjectBounds{}, err
+	}
+
+	for _, bounds := range parseObjectBounds(res) {
+		if bounds.ID == id {
+			return bounds, nil
+		}
+	}
+
+	return ObjectBounds{}, fmt.Errorf("object `%s` not found", id)
+}
+
+// SelectList returns the objects currently selected in the active
+// document.
+func (p *Proxy) SelectList(ctx context.Context) ([]ObjectInfo, error) {
+	pipeline := p.NewPipeline()
+	pipeline.AddRaw(SelectList())
+
+	res, err := p.SendPipeline(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseObjectInfo(res), nil
+}
+
+// parseObjectBounds parses the comma-separated "id,x,y,width,height"
+// rows inkscape emits for query-all, converting each numeric field and
+// skipping the trailing shell prompt.
+func parseObjectBounds(raw []byte) []ObjectBounds {
+	var bounds []ObjectBounds
+
+	for _, line := range strings.Split(string(raw), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || isPrompt([]byte(line)) {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) != 5 {
+			continue
+		}
+
+		x, errX := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+		y, errY := strconv.ParseFloat(strings.TrimSpace(fields[2]), 64)
+		width, errW := strconv.ParseFloat(strings.TrimSpace(fields[3]), 64)
+		height, errH := strconv.ParseFloat(strings.TrimSpace(fields[4]), 64)
+		if errX != nil || errY != nil || errW != nil || errH != nil {
+			continue
+		}
+
+		bounds = append(bounds, ObjectBounds{
+			ID:     strings.TrimSpace(fields[0]),
+			X:      x,
+			Y:      y,
+			Width:  width,
+			Height: height,
+		})
+	}
+
+	return bounds
+}
+
+// parseObjectInfo parses the one-id-per-line output of select-list.
+func parseObjectInfo(raw []byte) []ObjectInfo {
+	var objects []ObjectInfo
+
+	for _, line := range strings.Split(string(raw), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || isPrompt([]byte(line)) {
+			continue
+		}
+
+		objects = append(objects, ObjectInfo{ID: line})
+	}
+
+	return objects
+}
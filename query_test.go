@@ -0,0 +1,66 @@
+package inkscape
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseObjectBounds(t *testing.T) {
+	raw := []byte("rect1,10,20,30,40\ncircle1,1.5,2.5,3.5,4.5\n> ")
+
+	bounds := parseObjectBounds(raw)
+	if len(bounds) != 2 {
+		t.Fatalf("got %d bounds, want 2", len(bounds))
+	}
+
+	want := ObjectBounds{ID: "rect1", X: 10, Y: 20, Width: 30, Height: 40}
+	if bounds[0] != want {
+		t.Errorf("bounds[0] = %+v, want %+v", bounds[0], want)
+	}
+
+	if bounds[1].ID != "circle1" || bounds[1].X != 1.5 {
+		t.Errorf("bounds[1] = %+v", bounds[1])
+	}
+}
+
+func TestParseObjectBoundsSkipsMalformedRows(t *testing.T) {
+	raw := []byte("rect1,10,20,30,40\nnot,enough,fields\nrect2,bad,20,30,40\n")
+
+	bounds := parseObjectBounds(raw)
+	if len(bounds) != 1 {
+		t.Fatalf("got %d bounds, want 1 (malformed rows skipped)", len(bounds))
+	}
+}
+
+func TestParseObjectInfo(t *testing.T) {
+	raw := []byte("rect1\ncircle1\n> ")
+
+	objects := parseObjectInfo(raw)
+	if len(objects) != 2 {
+		t.Fatalf("got %d objects, want 2", len(objects))
+	}
+
+	if objects[0].ID != "rect1" || objects[1].ID != "circle1" {
+		t.Errorf("objects = %+v", objects)
+	}
+}
+
+func TestConvertUnit(t *testing.T) {
+	cases := []struct {
+		value    float64
+		from, to Unit
+		want     float64
+	}{
+		{value: 96, from: UnitPx, to: UnitIn, want: 1},
+		{value: 1, from: UnitIn, to: UnitPx, want: 96},
+		{value: 25.4, from: UnitMM, to: UnitIn, want: 1},
+		{value: 1, from: UnitPx, to: UnitPx, want: 1},
+	}
+
+	for _, c := range cases {
+		got := ConvertUnit(c.value, c.from, c.to)
+		if math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("ConvertUnit(%v, %v, %v) = %v, want %v", c.value, c.from, c.to, got, c.want)
+		}
+	}
+}